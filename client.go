@@ -2,11 +2,14 @@ package go_printify
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 )
 
 const (
@@ -23,11 +26,20 @@ type ApiRequest interface {
 }
 
 type Client struct {
-	BaseURL    *url.URL
-	ApiVersion string
-	UserAgent  string
-	httpClient *http.Client
-	apiKey     string
+	BaseURL          *url.URL
+	ApiVersion       string
+	UserAgent        string
+	RetryPolicy      RetryPolicy
+	IdempotencyStore IdempotencyStore
+	IdempotencyTTL   time.Duration
+	httpClient       *http.Client
+	apiKey           string
+	limiter          *RateLimiter
+
+	mu            sync.Mutex
+	deadlineTimer *time.Timer
+	deadlineCh    chan struct{} // closed when the armed deadline fires
+	changedCh     chan struct{} // closed when SetDeadline supersedes deadlineCh, to wake watchers
 }
 
 func NewClient(apiKey string) *Client {
@@ -36,15 +48,139 @@ func NewClient(apiKey string) *Client {
 			Scheme: scheme,
 			Host:   baseURL,
 		},
-		UserAgent:  "alhasaniq/go-printify v1.0.2",
-		httpClient: http.DefaultClient,
-		apiKey:     apiKey,
-		ApiVersion: "v1",
+		UserAgent:        "alhasaniq/go-printify v1.0.2",
+		httpClient:       http.DefaultClient,
+		apiKey:           apiKey,
+		ApiVersion:       "v1",
+		RetryPolicy:      DefaultRetryPolicy(),
+		IdempotencyStore: NewMemoryIdempotencyStore(),
+		IdempotencyTTL:   DefaultIdempotencyTTL,
+	}
+}
+
+// SetHTTPClient overrides the http.Client used for outgoing requests,
+// letting callers plug in a custom transport (e.g. for proxies or
+// instrumentation) or a client with its own timeout configured.
+func (c *Client) SetHTTPClient(hc *http.Client) {
+	c.httpClient = hc
+}
+
+// SetTimeout arms a client-wide deadline d from now. It is equivalent
+// to calling SetDeadline(time.Now().Add(d)).
+func (c *Client) SetTimeout(d time.Duration) {
+	c.SetDeadline(time.Now().Add(d))
+}
+
+// SetDeadline arms a client-wide deadline at t. Every request made
+// through the client is canceled once the deadline fires. Calling
+// SetDeadline again before the previous deadline fires resets the
+// timer, mirroring net.Conn.SetDeadline semantics, and wakes any
+// in-flight requests waiting on the superseded deadline so they pick up
+// the new one instead of leaking.
+func (c *Client) SetDeadline(t time.Time) {
+	c.mu.Lock()
+	if c.deadlineTimer != nil {
+		c.deadlineTimer.Stop()
+	}
+	ch := make(chan struct{})
+	c.deadlineCh = ch
+	c.deadlineTimer = time.AfterFunc(time.Until(t), func() {
+		close(ch)
+	})
+	oldChanged := c.changedCh
+	c.changedCh = make(chan struct{})
+	c.mu.Unlock()
+
+	if oldChanged != nil {
+		close(oldChanged)
+	}
+}
+
+// snapshot returns the channels a watcher should currently wait on: the
+// one closed when the armed deadline fires, and the one closed when
+// that pairing is superseded by a later SetDeadline/SetTimeout call.
+func (c *Client) snapshot() (deadlineCh, changedCh chan struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.deadlineCh, c.changedCh
+}
+
+// WithTimeout returns a copy of ctx that is canceled after d, along with
+// its cancel func, for scoping an individual call instead of the whole
+// client.
+func WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, d)
+}
+
+// WithDeadline returns a copy of ctx that is canceled at t, along with
+// its cancel func, for scoping an individual call instead of the whole
+// client.
+func WithDeadline(ctx context.Context, t time.Time) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(ctx, t)
+}
+
+// withClientDeadline derives a context from ctx that is also canceled
+// when the client's SetTimeout/SetDeadline deadline (if any) fires. If
+// the deadline is reset before firing, the watcher picks up the new one
+// instead of blocking on the superseded channel forever. The returned
+// cancel func must be called once the request it guards is done, so the
+// watcher goroutine exits immediately instead of living until the
+// client-wide deadline eventually fires.
+func (c *Client) withClientDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadlineCh, changedCh := c.snapshot()
+	if deadlineCh == nil {
+		return ctx, func() {}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer cancel()
+		for {
+			select {
+			case <-deadlineCh:
+				return
+			case <-ctx.Done():
+				return
+			case <-changedCh:
+				deadlineCh, changedCh = c.snapshot()
+				if deadlineCh == nil {
+					return
+				}
+			}
+		}
+	}()
+	return ctx, cancel
+}
+
+// deadlineCancelKey stashes the cancel func for a request's derived
+// client-deadline context so do() can release the watcher goroutine
+// once the request/response cycle is finished, without changing every
+// newRequest call site's signature.
+type deadlineCancelKey struct{}
+
+func withDeadlineCancel(ctx context.Context, cancel context.CancelFunc) context.Context {
+	return context.WithValue(ctx, deadlineCancelKey{}, cancel)
+}
+
+func deadlineCancelFromContext(ctx context.Context) context.CancelFunc {
+	cancel, _ := ctx.Value(deadlineCancelKey{}).(context.CancelFunc)
+	return cancel
+}
+
+func (c *Client) setHeaders(req *http.Request, hasBody bool) {
+	if hasBody {
+		req.Header.Set("Content-Type", contentType)
 	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
 }
 
-func (c *Client) newRequest(method, path string, body interface{}) (*http.Request, error) {
-	rel := &url.URL{Path: fmt.Sprintf("%s/%s", c.ApiVersion, path)}
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	rel, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	rel.Path = fmt.Sprintf("%s/%s", c.ApiVersion, rel.Path)
 	u := c.BaseURL.ResolveReference(rel)
 	var buf io.ReadWriter
 	if body != nil {
@@ -54,30 +190,112 @@ func (c *Client) newRequest(method, path string, body interface{}) (*http.Reques
 			return nil, err
 		}
 	}
-	req, err := http.NewRequest(method, u.String(), buf)
+	deadlineCtx, cancel := c.withClientDeadline(ctx)
+	req, err := http.NewRequestWithContext(withDeadlineCancel(deadlineCtx, cancel), method, u.String(), buf)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
-	if body != nil {
-		req.Header.Set("Content-Type", contentType)
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	c.setHeaders(req, body != nil)
 	return req, nil
 }
 
-func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := c.httpClient.Do(req)
+// newAbsoluteRequest builds a GET request for rawURL as-is, without joining
+// it to c.BaseURL/ApiVersion. It's used to follow a fully-qualified
+// next_page_url a paginated response already points at.
+func (c *Client) newAbsoluteRequest(ctx context.Context, rawURL string) (*http.Request, error) {
+	deadlineCtx, cancel := c.withClientDeadline(ctx)
+	req, err := http.NewRequestWithContext(withDeadlineCancel(deadlineCtx, cancel), http.MethodGet, rawURL, nil)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-	if resp.StatusCode >= 400 {
-		return resp, fmt.Errorf("printify API request failed with status:%d", resp.StatusCode)
+	c.setHeaders(req, false)
+	return req, nil
+}
+
+// do sends req, retrying according to c.RetryPolicy and c.limiter, and
+// decodes a successful JSON response into v. idempotent marks whether
+// the request is safe to retry on its own merits (GETs always are);
+// non-idempotent requests are only retried if the caller opted in via
+// AllowRetry on the request's context.
+func (c *Client) do(req *http.Request, v interface{}, idempotent bool) (*http.Response, error) {
+	if cancel := deadlineCancelFromContext(req.Context()); cancel != nil {
+		defer cancel()
+	}
+
+	policy := c.RetryPolicy
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.RetryOn == nil {
+		policy.RetryOn = defaultRetryOn
+	}
+	retryable := idempotent || retryAllowed(req.Context())
+
+	var lastErr error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+		}
+
+		if c.limiter != nil {
+			if err := c.limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			if !retryable || attempt == policy.MaxAttempts || !policy.RetryOn(nil, err) {
+				return nil, err
+			}
+			if waitErr := sleepContext(req.Context(), backoffDelay(policy, attempt)); waitErr != nil {
+				return nil, waitErr
+			}
+			continue
+		}
+
+		if resp.StatusCode >= 400 {
+			apiErr := parseAPIError(resp)
+			delay := retryAfterDelay(resp)
+			_ = resp.Body.Close()
+			lastErr = apiErr
+			if !retryable || attempt == policy.MaxAttempts || !policy.RetryOn(resp, nil) {
+				return resp, apiErr
+			}
+			if delay <= 0 {
+				delay = backoffDelay(policy, attempt)
+			}
+			if waitErr := sleepContext(req.Context(), delay); waitErr != nil {
+				return nil, waitErr
+			}
+			continue
+		}
+
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if v == nil {
+			return resp, nil
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return resp, err
+		}
+		if len(body) == 0 {
+			// A successful response with an empty body (e.g. a 204, or
+			// a 200 with nothing to report) isn't a decode failure.
+			return resp, nil
+		}
+		return resp, json.Unmarshal(body, v)
 	}
-	err = json.NewDecoder(resp.Body).Decode(v)
-	return resp, err
+	return nil, lastErr
 }
@@ -0,0 +1,51 @@
+package go_printify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// A client-wide deadline armed via SetTimeout must not pin one
+// goroutine per request alive until it eventually fires; each
+// request's watcher should be released as soon as that request's
+// response cycle completes.
+func TestSetTimeoutDoesNotLeakWatcherGoroutines(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/shops/1/orders/1.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Order{Id: strPtr("order-1")})
+	})
+	c := newTestClient(t, mux)
+	c.SetTimeout(time.Hour)
+
+	runtime.GC()
+	before := runtime.NumGoroutine()
+
+	const requests = 50
+	for i := 0; i < requests; i++ {
+		if _, err := c.GetOrderDetails(context.Background(), 1, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Watcher goroutines exit asynchronously once canceled; give them a
+	// moment before sampling.
+	deadline := time.Now().Add(time.Second)
+	after := before
+	for time.Now().Before(deadline) {
+		runtime.GC()
+		after = runtime.NumGoroutine()
+		if after <= before+5 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if after > before+5 {
+		t.Fatalf("goroutine count grew from %d to %d after %d requests; deadline watchers are leaking", before, after, requests)
+	}
+}
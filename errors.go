@@ -0,0 +1,84 @@
+package go_printify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Sentinel errors callers can match against a returned *APIError with
+// errors.Is, e.g. errors.Is(err, go_printify.ErrNotFound).
+var (
+	ErrNotFound     = fmt.Errorf("printify: resource not found")
+	ErrUnauthorized = fmt.Errorf("printify: unauthorized")
+	ErrRateLimited  = fmt.Errorf("printify: rate limited")
+)
+
+// APIError represents a non-2xx response from the Printify API.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	// Errors holds field-level validation errors, as returned on 422
+	// responses to shape-invalid payloads.
+	Errors    map[string][]string
+	RequestID string
+	Body      []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("printify API request failed with status:%d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("printify API request failed with status:%d", e.StatusCode)
+}
+
+// Unwrap lets errors.Is match well-known status codes against the
+// ErrNotFound/ErrUnauthorized/ErrRateLimited sentinels.
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// IsRetryable reports whether the error represents a transient
+// condition (429 or 5xx) rather than a permanent rejection of the
+// request. It mirrors the rule Client.do's default RetryPolicy uses.
+func (e *APIError) IsRetryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// errorEnvelope is the JSON shape Printify returns on non-2xx
+// responses: {"status":"error","code":"...","message":"...","errors":{...}}.
+type errorEnvelope struct {
+	Status  string              `json:"status"`
+	Code    string              `json:"code"`
+	Message string              `json:"message"`
+	Errors  map[string][]string `json:"errors"`
+}
+
+// parseAPIError reads and decodes resp's body into an APIError. It
+// does not close resp.Body; the caller remains responsible for that.
+func parseAPIError(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(resp.Body)
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Body:       body,
+	}
+	var env errorEnvelope
+	if err := json.Unmarshal(body, &env); err == nil {
+		apiErr.Code = env.Code
+		apiErr.Message = env.Message
+		apiErr.Errors = env.Errors
+	}
+	return apiErr
+}
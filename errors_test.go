@@ -0,0 +1,77 @@
+package go_printify
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// parseAPIError must decode Printify's error envelope into the
+// corresponding APIError fields.
+func TestParseAPIErrorDecodesEnvelope(t *testing.T) {
+	body := `{"status":"error","code":"invalid_request","message":"Validation failed","errors":{"line_items":["required"]}}`
+	resp := &http.Response{
+		StatusCode: http.StatusUnprocessableEntity,
+		Header:     http.Header{"X-Request-Id": []string{"req-123"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	apiErr := parseAPIError(resp)
+
+	if apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusUnprocessableEntity)
+	}
+	if apiErr.Code != "invalid_request" {
+		t.Errorf("Code = %q, want %q", apiErr.Code, "invalid_request")
+	}
+	if apiErr.Message != "Validation failed" {
+		t.Errorf("Message = %q, want %q", apiErr.Message, "Validation failed")
+	}
+	if apiErr.RequestID != "req-123" {
+		t.Errorf("RequestID = %q, want %q", apiErr.RequestID, "req-123")
+	}
+	if got := apiErr.Errors["line_items"]; len(got) != 1 || got[0] != "required" {
+		t.Errorf("Errors[line_items] = %v, want [required]", got)
+	}
+}
+
+// parseAPIError must not fail on a non-JSON or empty body; it should
+// still carry the status code and raw body.
+func TestParseAPIErrorToleratesUnparseableBody(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusBadGateway,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("<html>bad gateway</html>")),
+	}
+
+	apiErr := parseAPIError(resp)
+
+	if apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusBadGateway)
+	}
+	if apiErr.Message != "" {
+		t.Errorf("Message = %q, want empty", apiErr.Message)
+	}
+	if string(apiErr.Body) != "<html>bad gateway</html>" {
+		t.Errorf("Body = %q, want raw response body preserved", apiErr.Body)
+	}
+}
+
+func TestAPIErrorUnwrapMatchesSentinels(t *testing.T) {
+	cases := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusTooManyRequests, ErrRateLimited},
+	}
+	for _, tc := range cases {
+		err := &APIError{StatusCode: tc.status}
+		if !errors.Is(err, tc.want) {
+			t.Errorf("status %d: errors.Is did not match the expected sentinel", tc.status)
+		}
+	}
+}
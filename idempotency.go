@@ -0,0 +1,65 @@
+package go_printify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultIdempotencyTTL is how long a SubmitOrder response is kept for
+// replay under its idempotency key when the client doesn't override it.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyStore persists the order ID a SubmitOrder call returned
+// for a given idempotency key, so a retried call with the same key
+// replays that ID instead of submitting a duplicate order. Callers can
+// supply a Redis-backed (or other shared) implementation to make
+// idempotency hold across processes; the client defaults to an
+// in-memory store.
+type IdempotencyStore interface {
+	Get(ctx context.Context, key string) (orderID string, ok bool, err error)
+	Put(ctx context.Context, key, orderID string, ttl time.Duration) error
+}
+
+type memoryIdempotencyEntry struct {
+	orderID string
+	expires time.Time
+}
+
+// memoryIdempotencyStore is the default, process-local IdempotencyStore.
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryIdempotencyEntry
+}
+
+// NewMemoryIdempotencyStore returns an in-memory IdempotencyStore. It
+// does not evict expired entries proactively; they're skipped on Get
+// and overwritten on the next Put for the same key.
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{entries: make(map[string]memoryIdempotencyEntry)}
+}
+
+func (s *memoryIdempotencyStore) Get(_ context.Context, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return "", false, nil
+	}
+	return entry.orderID, true, nil
+}
+
+func (s *memoryIdempotencyStore) Put(_ context.Context, key, orderID string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = memoryIdempotencyEntry{orderID: orderID, expires: time.Now().Add(ttl)}
+	return nil
+}
+
+// WithIdempotencyStore overrides the store used to replay SubmitOrder
+// responses and returns the client so calls can be chained off
+// NewClient.
+func (c *Client) WithIdempotencyStore(store IdempotencyStore) *Client {
+	c.IdempotencyStore = store
+	return c
+}
@@ -0,0 +1,73 @@
+package go_printify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+// A repeated SubmitOrderWithOptions call using the same idempotency key
+// must replay the first call's order ID instead of submitting a second
+// order.
+func TestSubmitOrderWithOptionsReplaysOnRepeatedKey(t *testing.T) {
+	var calls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/shops/1/orders.json", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Order{Id: strPtr("order-1")})
+	})
+	c := newTestClient(t, mux)
+	opts := OrderSubmitOptions{IdempotencyKey: "key-1"}
+
+	id1, err := c.SubmitOrderWithOptions(context.Background(), 1, &Order{}, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	id2, err := c.SubmitOrderWithOptions(context.Background(), 1, &Order{}, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if id1 == nil || id2 == nil || *id1 != *id2 {
+		t.Fatalf("got ids %v, %v; want a matching replayed ID", id1, id2)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("server saw %d calls, want 1 (second call should have replayed)", got)
+	}
+}
+
+// A different idempotency key must submit a distinct order rather than
+// replaying an unrelated one.
+func TestSubmitOrderWithOptionsSubmitsSeparatelyForDistinctKeys(t *testing.T) {
+	var calls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/shops/1/orders.json", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if n == 1 {
+			_ = json.NewEncoder(w).Encode(Order{Id: strPtr("order-1")})
+		} else {
+			_ = json.NewEncoder(w).Encode(Order{Id: strPtr("order-2")})
+		}
+	})
+	c := newTestClient(t, mux)
+
+	id1, err := c.SubmitOrderWithOptions(context.Background(), 1, &Order{}, OrderSubmitOptions{IdempotencyKey: "key-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	id2, err := c.SubmitOrderWithOptions(context.Background(), 1, &Order{}, OrderSubmitOptions{IdempotencyKey: "key-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *id1 == *id2 {
+		t.Fatalf("got same id %q for distinct keys, want separate submissions", *id1)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("server saw %d calls, want 2", got)
+	}
+}
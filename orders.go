@@ -1,9 +1,8 @@
 package go_printify
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"time"
 )
@@ -102,117 +101,124 @@ type ShippingCost struct {
 /*
 Retrieve a list of orders
 */
-func (c *Client) ListShopOrders(shopId int, page, limit *int, statusFilter *string) ([]*Order, error) {
-	path := fmt.Sprintf(getShopOrdersPath, shopId)
-	if page != nil || limit != nil || statusFilter != nil {
-		path = fmt.Sprintf("%s?", path)
-	}
-	if page != nil {
-		path = fmt.Sprintf("%spage=%d", path, *page)
-	}
-
-	if limit != nil {
-		path = fmt.Sprintf("%s&limit=%d", path, *limit)
-	}
-
-	if statusFilter != nil {
-		path = fmt.Sprintf("%s&status=%s", path, *statusFilter)
-	}
-
-	req, err := c.newRequest(http.MethodGet, path, nil)
+func (c *Client) ListShopOrders(ctx context.Context, shopId int, page, limit *int, statusFilter *string) ([]*Order, error) {
+	path := ordersPath(shopId, OrderListOptions{Page: page, Limit: limit, Status: statusFilter})
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
-	orderList := make([]*Order, 0)
-	_, err = c.do(req, &orderList)
-	return orderList, err
+	var result orderPage
+	if _, err := c.do(req, &result, true); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
 }
 
 /*
 Get order details by ID
 */
-func (c *Client) GetOrderDetails(shopId, orderId int) (*Order, error) {
+func (c *Client) GetOrderDetails(ctx context.Context, shopId, orderId int) (*Order, error) {
 	path := fmt.Sprintf(getShopOrderPath, shopId, orderId)
-	req, err := c.newRequest(http.MethodGet, path, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
 	order := &Order{}
-	_, err = c.do(req, order)
+	_, err = c.do(req, order, true)
 	return order, err
 }
 
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// OrderSubmitOptions configures a single SubmitOrderWithOptions call.
+type OrderSubmitOptions struct {
+	// IdempotencyKey, when set, is sent as the Idempotency-Key header
+	// and used to replay a prior response if the same key is reused
+	// within the client's IdempotencyTTL, instead of submitting a
+	// duplicate order.
+	IdempotencyKey string
+}
+
 /*
 SubmitOrder to printify API
 returns Printify order ID
 */
-func (c *Client) SubmitOrder(shopId int, order *Order) (*string, error) {
+func (c *Client) SubmitOrder(ctx context.Context, shopId int, order *Order) (*string, error) {
+	return c.SubmitOrderWithOptions(ctx, shopId, order, OrderSubmitOptions{})
+}
+
+/*
+SubmitOrderWithOptions behaves like SubmitOrder but additionally
+supports an idempotency key, so callers retrying a submission that may
+have already gone through don't double-fulfill the order.
+*/
+func (c *Client) SubmitOrderWithOptions(ctx context.Context, shopId int, order *Order, opts OrderSubmitOptions) (*string, error) {
+	if opts.IdempotencyKey != "" && c.IdempotencyStore != nil {
+		if orderID, ok, err := c.IdempotencyStore.Get(ctx, opts.IdempotencyKey); err != nil {
+			return nil, err
+		} else if ok {
+			return &orderID, nil
+		}
+		ctx = AllowRetry(ctx)
+	}
+
 	path := fmt.Sprintf(getShopOrdersPath, shopId)
-	req, err := c.newRequest(http.MethodPost, path, order)
+	req, err := c.newRequest(ctx, http.MethodPost, path, order)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.do(req, order)
-
-	if err != nil {
-		body, _ := ioutil.ReadAll(resp.Body)
-		// {
-		// 	"id": "5a96f649b2439217d070f507"
-		// }
-
-		var responseData OrderSubmitResponse
-		err = json.Unmarshal(body, &responseData)
-		if err != nil {
-			return nil, err
-		}
-		return responseData.ID, nil
+	if opts.IdempotencyKey != "" {
+		req.Header.Set(idempotencyKeyHeader, opts.IdempotencyKey)
+	}
 
+	if _, err := c.do(req, order, false); err != nil {
+		return nil, err
 	}
-	return nil, err
-}
 
-type OrderSubmitResponse struct {
-	ID *string `json:"id"`
+	if opts.IdempotencyKey != "" && c.IdempotencyStore != nil && order.Id != nil {
+		_ = c.IdempotencyStore.Put(ctx, opts.IdempotencyKey, *order.Id, c.IdempotencyTTL)
+	}
+	return order.Id, nil
 }
 
 /*
 Send an existing order to production
 */
-func (c *Client) SendOrderToProduction(shopId, orderId int) (*Order, error) {
+func (c *Client) SendOrderToProduction(ctx context.Context, shopId, orderId int) (*Order, error) {
 	path := fmt.Sprintf(sendOrderToProductionPath, shopId, orderId)
-	req, err := c.newRequest(http.MethodPost, path, nil)
+	req, err := c.newRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
 		return nil, err
 	}
 	order := &Order{}
-	_, err = c.do(req, order)
+	_, err = c.do(req, order, false)
 	return order, err
 }
 
 /*
 Calculate the shipping cost of an order
 */
-func (c *Client) CalculateShippingCosts(shopId int, order *Order) (*ShippingCost, error) {
+func (c *Client) CalculateShippingCosts(ctx context.Context, shopId int, order *Order) (*ShippingCost, error) {
 	path := fmt.Sprintf(getShippingCostsPath, shopId)
-	req, err := c.newRequest(http.MethodPost, path, order)
+	req, err := c.newRequest(ctx, http.MethodPost, path, order)
 	if err != nil {
 		return nil, err
 	}
 	shippingCost := &ShippingCost{}
-	_, err = c.do(req, shippingCost)
+	_, err = c.do(req, shippingCost, true)
 	return shippingCost, err
 }
 
 /*
 Cancel an order
 */
-func (c *Client) CancelOrder(shopId, orderId int) (*Order, error) {
+func (c *Client) CancelOrder(ctx context.Context, shopId, orderId int) (*Order, error) {
 	path := fmt.Sprintf(cancelOrderPath, shopId, orderId)
-	req, err := c.newRequest(http.MethodPost, path, nil)
+	req, err := c.newRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
 		return nil, err
 	}
 	order := &Order{}
-	_, err = c.do(req, order)
+	_, err = c.do(req, order, false)
 	return order, err
 }
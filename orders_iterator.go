@@ -0,0 +1,135 @@
+package go_printify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// OrderListOptions filters and paginates a shop's order listing.
+type OrderListOptions struct {
+	Page   *int
+	Limit  *int
+	Status *string
+}
+
+func (o OrderListOptions) values() url.Values {
+	v := url.Values{}
+	if o.Page != nil {
+		v.Set("page", strconv.Itoa(*o.Page))
+	}
+	if o.Limit != nil {
+		v.Set("limit", strconv.Itoa(*o.Limit))
+	}
+	if o.Status != nil {
+		v.Set("status", *o.Status)
+	}
+	return v
+}
+
+func ordersPath(shopId int, opts OrderListOptions) string {
+	path := fmt.Sprintf(getShopOrdersPath, shopId)
+	if q := opts.values().Encode(); q != "" {
+		path = fmt.Sprintf("%s?%s", path, q)
+	}
+	return path
+}
+
+// orderPage is the pagination envelope Printify wraps an order listing
+// in, trimmed to the fields the iterator needs.
+type orderPage struct {
+	Data        []*Order `json:"data"`
+	NextPageUrl string   `json:"next_page_url"`
+}
+
+// OrderIterator lazily walks a paginated order listing, fetching the
+// next page only once the current one is exhausted.
+type OrderIterator struct {
+	ctx     context.Context
+	client  *Client
+	nextURL string
+	started bool
+	items   []*Order
+	cur     *Order
+	err     error
+}
+
+// OrdersIterator returns an OrderIterator over shopId's orders matching
+// opts, following Printify's next_page_url until exhausted.
+func (c *Client) OrdersIterator(ctx context.Context, shopId int, opts OrderListOptions) *OrderIterator {
+	return &OrderIterator{
+		ctx:     ctx,
+		client:  c,
+		nextURL: ordersPath(shopId, opts),
+	}
+}
+
+// Next advances the iterator, fetching another page if the current one
+// is exhausted. It returns false once iteration is done or an error
+// occurred; call Err to tell the two apart.
+func (it *OrderIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.err != nil {
+			return false
+		}
+		if it.started && it.nextURL == "" {
+			return false
+		}
+		it.started = true
+		if !it.fetch() {
+			return false
+		}
+	}
+	it.cur, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+func (it *OrderIterator) fetch() bool {
+	var req *http.Request
+	var err error
+	if u, parseErr := url.Parse(it.nextURL); parseErr == nil && u.IsAbs() {
+		req, err = it.client.newAbsoluteRequest(it.ctx, it.nextURL)
+	} else {
+		req, err = it.client.newRequest(it.ctx, http.MethodGet, it.nextURL, nil)
+	}
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	var page orderPage
+	if _, err := it.client.do(req, &page, true); err != nil {
+		it.err = err
+		return false
+	}
+	it.items = page.Data
+	it.nextURL = page.NextPageUrl
+	return true
+}
+
+// Order returns the order Next just advanced to.
+func (it *OrderIterator) Order() *Order {
+	return it.cur
+}
+
+// Err returns the error, if any, that stopped iteration.
+func (it *OrderIterator) Err() error {
+	return it.err
+}
+
+// AllOrders materializes every order matching opts by draining an
+// OrderIterator. Prefer OrdersIterator directly for large shops where
+// holding every order in memory at once is wasteful.
+func (c *Client) AllOrders(ctx context.Context, shopId int, opts OrderListOptions) ([]*Order, error) {
+	it := c.OrdersIterator(ctx, shopId, opts)
+	var all []*Order
+	for it.Next() {
+		all = append(all, it.Order())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
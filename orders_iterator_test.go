@@ -0,0 +1,100 @@
+package go_printify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parse test server URL: %v", err)
+	}
+	c := NewClient("test-key")
+	c.BaseURL = u
+	return c
+}
+
+func strPtr(s string) *string { return &s }
+
+// twoPageOrdersHandler serves the Printify Page envelope across two
+// pages, with the first page's next_page_url pointing at the second.
+func twoPageOrdersHandler(t *testing.T) (http.Handler, func(base string)) {
+	t.Helper()
+	var page2URL string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/shops/1/orders.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			_ = json.NewEncoder(w).Encode(orderPage{
+				Data: []*Order{{Id: strPtr("order-2")}},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(orderPage{
+			Data:        []*Order{{Id: strPtr("order-1")}},
+			NextPageUrl: page2URL,
+		})
+	})
+	return mux, func(base string) { page2URL = base + "/v1/shops/1/orders.json?page=2" }
+}
+
+func TestOrdersIteratorPaginatesAcrossPages(t *testing.T) {
+	mux, setPage2URL := twoPageOrdersHandler(t)
+	c := newTestClient(t, mux)
+	setPage2URL(c.BaseURL.String())
+
+	it := c.OrdersIterator(context.Background(), 1, OrderListOptions{})
+	var got []string
+	for it.Next() {
+		got = append(got, *it.Order().Id)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "order-1" || got[1] != "order-2" {
+		t.Fatalf("got %v, want [order-1 order-2]", got)
+	}
+}
+
+func TestAllOrdersMaterializesEveryPage(t *testing.T) {
+	mux, setPage2URL := twoPageOrdersHandler(t)
+	c := newTestClient(t, mux)
+	setPage2URL(c.BaseURL.String())
+
+	orders, err := c.AllOrders(context.Background(), 1, OrderListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(orders) != 2 || *orders[0].Id != "order-1" || *orders[1].Id != "order-2" {
+		t.Fatalf("got %v, want [order-1 order-2]", orders)
+	}
+}
+
+// ListShopOrders must decode the Page envelope Printify actually
+// returns, not a flat array.
+func TestListShopOrdersDecodesPageEnvelope(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/shops/1/orders.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(orderPage{
+			Data: []*Order{{Id: strPtr("order-1")}},
+		})
+	})
+	c := newTestClient(t, mux)
+
+	orders, err := c.ListShopOrders(context.Background(), 1, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(orders) != 1 || *orders[0].Id != "order-1" {
+		t.Fatalf("got %v, want [order-1]", orders)
+	}
+}
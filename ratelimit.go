@@ -0,0 +1,67 @@
+package go_printify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a minimal token-bucket limiter, modeled on
+// golang.org/x/time/rate.Limiter's Wait semantics, for throttling bulk
+// calls (e.g. SubmitOrder loops) in-process instead of relying solely
+// on retry-after backoff.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter that allows eventsPerSecond
+// sustained requests with bursts up to burst.
+func NewRateLimiter(eventsPerSecond float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:   eventsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait, ok := l.take()
+		if ok {
+			return nil
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// take attempts to consume a token, returning how long to wait before
+// trying again if none is available.
+func (l *RateLimiter) take() (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second)), false
+}
@@ -0,0 +1,119 @@
+package go_printify
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how Client.do retries a failed request.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first.
+	MaxAttempts int
+	// BaseDelay bounds the delay before the first retry; the bound
+	// doubles on each subsequent attempt up to MaxDelay, and the actual
+	// delay is drawn at random between zero and that bound.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay.
+	MaxDelay time.Duration
+	// RetryOn decides whether a completed attempt should be retried.
+	// resp is nil when err is a transport-level error.
+	RetryOn func(resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy makes up to 3 attempts in all (the first try plus
+// at most 2 retries) on network errors, HTTP 429,
+// or 5xx responses, backing off exponentially with jitter between
+// attempts and honoring any Retry-After header Printify sends.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		RetryOn:     defaultRetryOn,
+	}
+}
+
+func defaultRetryOn(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500)
+}
+
+// WithRetryPolicy overrides the client's retry policy and returns the
+// client so calls can be chained off NewClient.
+func (c *Client) WithRetryPolicy(p RetryPolicy) *Client {
+	c.RetryPolicy = p
+	return c
+}
+
+// WithLimiter attaches a rate limiter that every request waits on
+// before being sent, so bulk loops (e.g. repeated SubmitOrder or
+// CalculateShippingCosts calls) don't exceed Printify's rate limits. A
+// nil limiter, the default, disables throttling.
+func (c *Client) WithLimiter(l *RateLimiter) *Client {
+	c.limiter = l
+	return c
+}
+
+type allowRetryKey struct{}
+
+// AllowRetry marks ctx as safe to retry non-idempotent requests (e.g. a
+// SubmitOrder call the caller knows is safe to resend, such as one
+// guarded by an idempotency key) on top of the client's RetryPolicy.
+func AllowRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowRetryKey{}, true)
+}
+
+func retryAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(allowRetryKey{}).(bool)
+	return allowed
+}
+
+// backoffDelay computes a full-jitter exponential backoff delay for the
+// given attempt (1-indexed).
+func backoffDelay(policy RetryPolicy, attempt int) time.Duration {
+	delay := policy.BaseDelay << uint(attempt-1)
+	if delay <= 0 || delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// retryAfterDelay parses a Retry-After header, which Printify sends as
+// either a number of seconds or an HTTP-date.
+func retryAfterDelay(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// sleepContext waits for d, returning early with ctx.Err() if ctx is
+// done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
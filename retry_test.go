@@ -0,0 +1,74 @@
+package go_printify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Client.do must retry a 429 honoring Retry-After, and a 5xx with
+// backoff, before eventually succeeding.
+func TestDoRetriesRateLimitAndServerErrorThenSucceeds(t *testing.T) {
+	var attempts int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/shops/1/orders/1.json", func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&attempts, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(Order{Id: strPtr("order-1")})
+		}
+	})
+	c := newTestClient(t, mux)
+	c.RetryPolicy.MaxAttempts = 3
+	c.RetryPolicy.BaseDelay = time.Millisecond
+	c.RetryPolicy.MaxDelay = 5 * time.Millisecond
+
+	order, err := c.GetOrderDetails(context.Background(), 1, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.Id == nil || *order.Id != "order-1" {
+		t.Fatalf("got %+v, want order-1", order)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("server saw %d attempts, want 3", got)
+	}
+}
+
+// Once MaxAttempts is exhausted, do must surface the last APIError
+// instead of retrying forever.
+func TestDoStopsRetryingAfterMaxAttempts(t *testing.T) {
+	var attempts int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/shops/1/orders/1.json", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c := newTestClient(t, mux)
+	c.RetryPolicy.MaxAttempts = 2
+	c.RetryPolicy.BaseDelay = time.Millisecond
+	c.RetryPolicy.MaxDelay = 5 * time.Millisecond
+
+	_, err := c.GetOrderDetails(context.Background(), 1, 1)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("got error of type %T, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusInternalServerError)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Fatalf("server saw %d attempts, want 2", got)
+	}
+}
@@ -0,0 +1,74 @@
+package go_printify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	listWebhooksPath  = "shops/%d/webhooks.json"
+	updateWebhookPath = "shops/%d/webhooks/%s.json"
+)
+
+type Webhook struct {
+	Id     *string `json:"id,omitempty"`
+	Topic  string  `json:"topic"`
+	Url    string  `json:"url"`
+	ShopId *string `json:"shop_id,omitempty"`
+}
+
+/*
+List the webhook subscriptions configured for a shop
+*/
+func (c *Client) ListWebhooks(ctx context.Context, shopId int) ([]*Webhook, error) {
+	path := fmt.Sprintf(listWebhooksPath, shopId)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	webhooks := make([]*Webhook, 0)
+	_, err = c.do(req, &webhooks, true)
+	return webhooks, err
+}
+
+/*
+Create a webhook subscription for a shop
+*/
+func (c *Client) CreateWebhook(ctx context.Context, shopId int, topic, url string) (*Webhook, error) {
+	path := fmt.Sprintf(listWebhooksPath, shopId)
+	req, err := c.newRequest(ctx, http.MethodPost, path, &Webhook{Topic: topic, Url: url})
+	if err != nil {
+		return nil, err
+	}
+	webhook := &Webhook{}
+	_, err = c.do(req, webhook, false)
+	return webhook, err
+}
+
+/*
+Update the target URL of an existing webhook subscription
+*/
+func (c *Client) UpdateWebhook(ctx context.Context, shopId int, webhookId, url string) (*Webhook, error) {
+	path := fmt.Sprintf(updateWebhookPath, shopId, webhookId)
+	req, err := c.newRequest(ctx, http.MethodPut, path, &Webhook{Url: url})
+	if err != nil {
+		return nil, err
+	}
+	webhook := &Webhook{}
+	_, err = c.do(req, webhook, true)
+	return webhook, err
+}
+
+/*
+Delete a webhook subscription
+*/
+func (c *Client) DeleteWebhook(ctx context.Context, shopId int, webhookId string) error {
+	path := fmt.Sprintf(updateWebhookPath, shopId, webhookId)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(req, nil, true)
+	return err
+}
@@ -0,0 +1,174 @@
+// Package webhooks implements an http.Handler that verifies and
+// dispatches Printify order-lifecycle webhook events.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	goprintify "github.com/alhasaniq/go-printify"
+)
+
+// Printify event topics, as delivered in the envelope's "type" field.
+const (
+	EventOrderCreated          = "order:created"
+	EventOrderUpdated          = "order:updated"
+	EventOrderSentToProduction = "order:sent-to-production"
+	EventOrderShipped          = "order:shipment:created"
+	EventOrderCanceled         = "order:canceled"
+)
+
+const (
+	signatureHeader = "X-Pfy-Signature"
+	timestampHeader = "X-Pfy-Timestamp"
+
+	// DefaultReplayWindow is how old an incoming request's timestamp
+	// header is allowed to be before it is rejected as a replay.
+	DefaultReplayWindow = 5 * time.Minute
+)
+
+var (
+	// ErrMissingSignature is returned when the request carries no signature header.
+	ErrMissingSignature = errors.New("webhooks: missing signature header")
+	// ErrInvalidSignature is returned when the computed HMAC does not match the header.
+	ErrInvalidSignature = errors.New("webhooks: invalid signature")
+	// ErrReplay is returned when the timestamp header falls outside the replay window.
+	ErrReplay = errors.New("webhooks: timestamp outside replay window")
+)
+
+// Event is the decoded payload handed to a registered handler func.
+type Event struct {
+	Type      string            `json:"type"`
+	CreatedAt time.Time         `json:"created_at"`
+	Order     *goprintify.Order `json:"resource"`
+}
+
+// HandlerFunc processes a single decoded webhook event.
+type HandlerFunc func(ctx context.Context, e *Event) error
+
+// Handler verifies and dispatches incoming Printify webhook requests.
+type Handler struct {
+	secret       string
+	replayWindow time.Duration
+	handlers     map[string][]HandlerFunc
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithReplayWindow overrides the default replay window used to reject
+// stale requests based on the X-Pfy-Timestamp header.
+func WithReplayWindow(d time.Duration) Option {
+	return func(h *Handler) {
+		h.replayWindow = d
+	}
+}
+
+// NewHandler returns a Handler that verifies requests using the given
+// per-shop webhook secret.
+func NewHandler(secret string, opts ...Option) *Handler {
+	h := &Handler{
+		secret:       secret,
+		replayWindow: DefaultReplayWindow,
+		handlers:     make(map[string][]HandlerFunc),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Register adds fn as a handler for the given event topic (e.g.
+// "order:created"). Multiple handlers may be registered for the same
+// topic and are invoked in registration order.
+func (h *Handler) Register(eventType string, fn HandlerFunc) {
+	h.handlers[eventType] = append(h.handlers[eventType], fn)
+}
+
+// OnOrderCreated registers fn for the order:created topic.
+func (h *Handler) OnOrderCreated(fn HandlerFunc) { h.Register(EventOrderCreated, fn) }
+
+// OnOrderUpdated registers fn for the order:updated topic.
+func (h *Handler) OnOrderUpdated(fn HandlerFunc) { h.Register(EventOrderUpdated, fn) }
+
+// OnOrderSentToProduction registers fn for the order:sent-to-production topic.
+func (h *Handler) OnOrderSentToProduction(fn HandlerFunc) {
+	h.Register(EventOrderSentToProduction, fn)
+}
+
+// OnOrderShipped registers fn for the order:shipment:created topic.
+func (h *Handler) OnOrderShipped(fn HandlerFunc) { h.Register(EventOrderShipped, fn) }
+
+// OnOrderCanceled registers fn for the order:canceled topic.
+func (h *Handler) OnOrderCanceled(fn HandlerFunc) { h.Register(EventOrderCanceled, fn) }
+
+// ServeHTTP verifies the request's signature and timestamp, decodes the
+// body into an Event, and invokes every handler registered for its
+// topic. It responds 400 if verification fails, 422 if the body can't
+// be decoded, 500 if a handler returns an error, and 200 otherwise.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	if err := h.verify(r, body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "failed to decode event", http.StatusUnprocessableEntity)
+		return
+	}
+
+	for _, fn := range h.handlers[event.Type] {
+		if err := fn(r.Context(), &event); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) verify(r *http.Request, body []byte) error {
+	sig := r.Header.Get(signatureHeader)
+	if sig == "" {
+		return ErrMissingSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+
+	ts := r.Header.Get(timestampHeader)
+	if ts == "" {
+		return ErrReplay
+	}
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrReplay
+	}
+	if time.Since(time.Unix(sec, 0)) > h.replayWindow {
+		return ErrReplay
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testSecret = "shop-secret"
+
+func sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newRequest(t *testing.T, body []byte, sig, ts string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/printify", bytes.NewReader(body))
+	if sig != "" {
+		req.Header.Set(signatureHeader, sig)
+	}
+	if ts != "" {
+		req.Header.Set(timestampHeader, ts)
+	}
+	return req
+}
+
+func TestServeHTTP_ValidRequestDispatchesHandler(t *testing.T) {
+	body := []byte(`{"type":"order:created","created_at":"2026-01-01T00:00:00Z"}`)
+	req := newRequest(t, body, sign(body), strconv.FormatInt(time.Now().Unix(), 10))
+
+	h := NewHandler(testSecret)
+	var gotType string
+	h.OnOrderCreated(func(_ context.Context, e *Event) error {
+		gotType = e.Type
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if gotType != EventOrderCreated {
+		t.Fatalf("handler saw type %q, want %q", gotType, EventOrderCreated)
+	}
+}
+
+func TestServeHTTP_MissingSignatureRejected(t *testing.T) {
+	body := []byte(`{"type":"order:created"}`)
+	req := newRequest(t, body, "", strconv.FormatInt(time.Now().Unix(), 10))
+
+	rec := httptest.NewRecorder()
+	NewHandler(testSecret).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestServeHTTP_InvalidSignatureRejected(t *testing.T) {
+	body := []byte(`{"type":"order:created"}`)
+	req := newRequest(t, body, "not-the-right-signature", strconv.FormatInt(time.Now().Unix(), 10))
+
+	rec := httptest.NewRecorder()
+	NewHandler(testSecret).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+// A correctly-signed request with no timestamp header must not be
+// accepted: that would disable replay protection entirely for an
+// attacker who simply omits the header.
+func TestServeHTTP_MissingTimestampRejected(t *testing.T) {
+	body := []byte(`{"type":"order:created"}`)
+	req := newRequest(t, body, sign(body), "")
+
+	rec := httptest.NewRecorder()
+	NewHandler(testSecret).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestServeHTTP_StaleTimestampRejected(t *testing.T) {
+	body := []byte(`{"type":"order:created"}`)
+	stale := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	req := newRequest(t, body, sign(body), stale)
+
+	rec := httptest.NewRecorder()
+	NewHandler(testSecret, WithReplayWindow(5*time.Minute)).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}